@@ -0,0 +1,336 @@
+// Package diff computes configuration drift between two point-in-time
+// scans produced by the scanner package.
+package diff
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// Severity ranks how urgent a Change is to review.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// Change describes one piece of configuration drift detected for a bucket
+// between two scans.
+type Change struct {
+	Bucket      string   `json:"bucket"`
+	Type        string   `json:"type"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+	Before      string   `json:"before,omitempty"`
+	After       string   `json:"after,omitempty"`
+}
+
+// Diff compares prev and curr and returns every detected Change, in no
+// particular order beyond grouping by bucket.
+func Diff(prev, curr []scanner.BucketInfo) []Change {
+	prevByName := indexByName(prev)
+	currByName := indexByName(curr)
+
+	var changes []Change
+
+	for name := range prevByName {
+		if _, ok := currByName[name]; !ok {
+			changes = append(changes, Change{
+				Bucket:      name,
+				Type:        "bucket_deleted",
+				Severity:    SeverityHigh,
+				Description: "bucket no longer appears in the scan",
+			})
+		}
+	}
+
+	for name, c := range currByName {
+		p, ok := prevByName[name]
+		if !ok {
+			changes = append(changes, Change{
+				Bucket:      name,
+				Type:        "bucket_added",
+				Severity:    SeverityInfo,
+				Description: "bucket appears for the first time",
+			})
+			continue
+		}
+		changes = append(changes, diffBucket(p, c)...)
+	}
+
+	return changes
+}
+
+func indexByName(infos []scanner.BucketInfo) map[string]scanner.BucketInfo {
+	m := make(map[string]scanner.BucketInfo, len(infos))
+	for _, info := range infos {
+		m[info.Name] = info
+	}
+	return m
+}
+
+func diffBucket(prev, curr scanner.BucketInfo) []Change {
+	var changes []Change
+	changes = append(changes, diffACL(prev, curr)...)
+	changes = append(changes, diffPolicy(prev, curr)...)
+	changes = append(changes, diffEncryption(prev, curr)...)
+	changes = append(changes, diffVersioning(prev, curr)...)
+	changes = append(changes, diffReplication(prev, curr)...)
+	changes = append(changes, diffPublicAccess(prev, curr)...)
+	return changes
+}
+
+// granteeKey identifies an ACL grant by grantee + permission so additions
+// and removals can be detected independently of grant ordering.
+func granteeKey(uri, id, permission string) string {
+	return uri + "|" + id + "|" + permission
+}
+
+func aclGrants(info scanner.BucketInfo) map[string]bool {
+	grants := map[string]bool{}
+	if info.ACL == nil {
+		return grants
+	}
+	for _, g := range info.ACL.Grants {
+		if g.Grantee == nil {
+			continue
+		}
+		key := granteeKey(aws.ToString(g.Grantee.URI), aws.ToString(g.Grantee.ID), string(g.Permission))
+		grants[key] = true
+	}
+	return grants
+}
+
+func diffACL(prev, curr scanner.BucketInfo) []Change {
+	var changes []Change
+	prevGrants := aclGrants(prev)
+	currGrants := aclGrants(curr)
+
+	for key := range currGrants {
+		if !prevGrants[key] {
+			changes = append(changes, Change{
+				Bucket:      curr.Name,
+				Type:        "acl_grantee_added",
+				Severity:    SeverityHigh,
+				Description: "ACL grant added: " + key,
+			})
+		}
+	}
+	for key := range prevGrants {
+		if !currGrants[key] {
+			changes = append(changes, Change{
+				Bucket:      curr.Name,
+				Type:        "acl_grantee_removed",
+				Severity:    SeverityMedium,
+				Description: "ACL grant removed: " + key,
+			})
+		}
+	}
+	return changes
+}
+
+// diffPolicy compares bucket policy statements after canonicalizing each to
+// JSON with alphabetically-ordered keys (via policyStatementSet), so two
+// policies differing only in key/statement order compare equal.
+func diffPolicy(prev, curr scanner.BucketInfo) []Change {
+	var changes []Change
+	prevStmts := policyStatementSet(prev)
+	currStmts := policyStatementSet(curr)
+
+	for stmt := range currStmts {
+		if !prevStmts[stmt] {
+			changes = append(changes, Change{
+				Bucket:      curr.Name,
+				Type:        "policy_statement_added",
+				Severity:    SeverityHigh,
+				Description: "bucket policy statement added",
+				After:       stmt,
+			})
+		}
+	}
+	for stmt := range prevStmts {
+		if !currStmts[stmt] {
+			changes = append(changes, Change{
+				Bucket:      curr.Name,
+				Type:        "policy_statement_removed",
+				Severity:    SeverityMedium,
+				Description: "bucket policy statement removed",
+				Before:      stmt,
+			})
+		}
+	}
+	return changes
+}
+
+func policyStatementSet(info scanner.BucketInfo) map[string]bool {
+	set := map[string]bool{}
+	if info.Policy == nil || info.Policy.Policy == nil {
+		return set
+	}
+	var doc struct {
+		Statement []json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal([]byte(aws.ToString(info.Policy.Policy)), &doc); err != nil {
+		return set
+	}
+	for _, raw := range doc.Statement {
+		var generic map[string]interface{}
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			continue
+		}
+		canon, err := json.Marshal(generic)
+		if err != nil {
+			continue
+		}
+		set[string(canon)] = true
+	}
+	return set
+}
+
+func diffEncryption(prev, curr scanner.BucketInfo) []Change {
+	prevAlgo := encryptionAlgorithm(prev)
+	currAlgo := encryptionAlgorithm(curr)
+	if prevAlgo == currAlgo {
+		return nil
+	}
+
+	severity := SeverityMedium
+	if currAlgo == "" {
+		severity = SeverityCritical
+	}
+
+	return []Change{{
+		Bucket:      curr.Name,
+		Type:        "encryption_changed",
+		Severity:    severity,
+		Description: "default encryption algorithm changed",
+		Before:      orNone(prevAlgo),
+		After:       orNone(currAlgo),
+	}}
+}
+
+func encryptionAlgorithm(info scanner.BucketInfo) string {
+	if info.EncryptionConfig == nil || info.EncryptionConfig.ServerSideEncryptionConfiguration == nil {
+		return ""
+	}
+	for _, rule := range info.EncryptionConfig.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault != nil {
+			return string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+		}
+	}
+	return ""
+}
+
+func diffVersioning(prev, curr scanner.BucketInfo) []Change {
+	prevStatus := versioningStatus(prev)
+	currStatus := versioningStatus(curr)
+	if prevStatus == currStatus {
+		return nil
+	}
+
+	severity := SeverityMedium
+	if prevStatus == "Enabled" && currStatus != "Enabled" {
+		severity = SeverityHigh
+	}
+
+	return []Change{{
+		Bucket:      curr.Name,
+		Type:        "versioning_toggled",
+		Severity:    severity,
+		Description: "bucket versioning status changed",
+		Before:      orNone(prevStatus),
+		After:       orNone(currStatus),
+	}}
+}
+
+func versioningStatus(info scanner.BucketInfo) string {
+	if info.VersioningConfig == nil {
+		return ""
+	}
+	return string(info.VersioningConfig.Status)
+}
+
+func replicationRuleIDs(info scanner.BucketInfo) map[string]bool {
+	ids := map[string]bool{}
+	if info.ReplicationConfig == nil || info.ReplicationConfig.ReplicationConfiguration == nil {
+		return ids
+	}
+	for _, rule := range info.ReplicationConfig.ReplicationConfiguration.Rules {
+		ids[aws.ToString(rule.ID)] = true
+	}
+	return ids
+}
+
+func diffReplication(prev, curr scanner.BucketInfo) []Change {
+	var changes []Change
+	prevIDs := replicationRuleIDs(prev)
+	currIDs := replicationRuleIDs(curr)
+
+	for id := range currIDs {
+		if !prevIDs[id] {
+			changes = append(changes, Change{
+				Bucket:      curr.Name,
+				Type:        "replication_rule_added",
+				Severity:    SeverityInfo,
+				Description: "replication rule added: " + id,
+			})
+		}
+	}
+	for id := range prevIDs {
+		if !currIDs[id] {
+			changes = append(changes, Change{
+				Bucket:      curr.Name,
+				Type:        "replication_rule_removed",
+				Severity:    SeverityMedium,
+				Description: "replication rule removed: " + id,
+			})
+		}
+	}
+	return changes
+}
+
+func diffPublicAccess(prev, curr scanner.BucketInfo) []Change {
+	prevPublic := isPublic(prev)
+	currPublic := isPublic(curr)
+	if prevPublic == currPublic {
+		return nil
+	}
+
+	severity := SeverityMedium
+	if currPublic {
+		severity = SeverityCritical
+	}
+
+	return []Change{{
+		Bucket:      curr.Name,
+		Type:        "public_access_flipped",
+		Severity:    severity,
+		Description: "bucket public-access status changed",
+		Before:      boolStr(prevPublic),
+		After:       boolStr(currPublic),
+	}}
+}
+
+func isPublic(info scanner.BucketInfo) bool {
+	return info.PolicyStatus != nil && info.PolicyStatus.PolicyStatus != nil && aws.ToBool(info.PolicyStatus.PolicyStatus.IsPublic)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func boolStr(b bool) string {
+	if b {
+		return "public"
+	}
+	return "private"
+}
@@ -0,0 +1,114 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/diff"
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+func findChange(t *testing.T, changes []diff.Change, changeType string) *diff.Change {
+	t.Helper()
+	for i := range changes {
+		if changes[i].Type == changeType {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func TestDiff_BucketAddedAndRemoved(t *testing.T) {
+	prev := []scanner.BucketInfo{{Name: "gone"}, {Name: "kept"}}
+	curr := []scanner.BucketInfo{{Name: "kept"}, {Name: "new"}}
+
+	changes := diff.Diff(prev, curr)
+
+	if c := findChange(t, changes, "bucket_deleted"); c == nil || c.Bucket != "gone" {
+		t.Errorf("expected bucket_deleted for %q, got %+v", "gone", changes)
+	}
+	if c := findChange(t, changes, "bucket_added"); c == nil || c.Bucket != "new" {
+		t.Errorf("expected bucket_added for %q, got %+v", "new", changes)
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	infos := []scanner.BucketInfo{{Name: "stable", VersioningConfig: &s3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled}}}
+
+	if changes := diff.Diff(infos, infos); len(changes) != 0 {
+		t.Errorf("expected no changes between identical scans, got %+v", changes)
+	}
+}
+
+func TestDiff_VersioningToggled(t *testing.T) {
+	prev := []scanner.BucketInfo{{Name: "b", VersioningConfig: &s3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled}}}
+	curr := []scanner.BucketInfo{{Name: "b", VersioningConfig: &s3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusSuspended}}}
+
+	changes := diff.Diff(prev, curr)
+
+	c := findChange(t, changes, "versioning_toggled")
+	if c == nil {
+		t.Fatalf("expected a versioning_toggled change, got %+v", changes)
+	}
+	if c.Severity != diff.SeverityHigh {
+		t.Errorf("expected disabling versioning to be high severity, got %s", c.Severity)
+	}
+	if c.Before != "Enabled" || c.After != "Suspended" {
+		t.Errorf("expected before=Enabled after=Suspended, got before=%q after=%q", c.Before, c.After)
+	}
+}
+
+func TestDiff_PolicyStatementOrderDoesNotCountAsDrift(t *testing.T) {
+	// Same two statements, reordered and with keys in a different order -
+	// policyStatementSet canonicalizes both, so this must not report drift.
+	prev := []scanner.BucketInfo{{Name: "b", Policy: &s3.GetBucketPolicyOutput{
+		Policy: aws.String(`{"Statement":[{"Effect":"Allow","Principal":"*"},{"Principal":"arn:aws:iam::111122223333:root","Effect":"Deny"}]}`),
+	}}}
+	curr := []scanner.BucketInfo{{Name: "b", Policy: &s3.GetBucketPolicyOutput{
+		Policy: aws.String(`{"Statement":[{"Principal":"arn:aws:iam::111122223333:root","Effect":"Deny"},{"Effect":"Allow","Principal":"*"}]}`),
+	}}}
+
+	if changes := diff.Diff(prev, curr); len(changes) != 0 {
+		t.Errorf("expected reordered-but-equivalent policy statements to produce no drift, got %+v", changes)
+	}
+}
+
+func TestDiff_PolicyStatementAdded(t *testing.T) {
+	prev := []scanner.BucketInfo{{Name: "b", Policy: &s3.GetBucketPolicyOutput{
+		Policy: aws.String(`{"Statement":[{"Effect":"Deny","Principal":"arn:aws:iam::111122223333:root"}]}`),
+	}}}
+	curr := []scanner.BucketInfo{{Name: "b", Policy: &s3.GetBucketPolicyOutput{
+		Policy: aws.String(`{"Statement":[{"Effect":"Deny","Principal":"arn:aws:iam::111122223333:root"},{"Effect":"Allow","Principal":"*"}]}`),
+	}}}
+
+	changes := diff.Diff(prev, curr)
+
+	if c := findChange(t, changes, "policy_statement_added"); c == nil {
+		t.Errorf("expected a policy_statement_added change, got %+v", changes)
+	}
+	if c := findChange(t, changes, "policy_statement_removed"); c != nil {
+		t.Errorf("did not expect a policy_statement_removed change, got %+v", c)
+	}
+}
+
+func TestDiff_PublicAccessFlipped(t *testing.T) {
+	prev := []scanner.BucketInfo{{Name: "b", PolicyStatus: &s3.GetBucketPolicyStatusOutput{
+		PolicyStatus: &types.PolicyStatus{IsPublic: aws.Bool(false)},
+	}}}
+	curr := []scanner.BucketInfo{{Name: "b", PolicyStatus: &s3.GetBucketPolicyStatusOutput{
+		PolicyStatus: &types.PolicyStatus{IsPublic: aws.Bool(true)},
+	}}}
+
+	changes := diff.Diff(prev, curr)
+
+	c := findChange(t, changes, "public_access_flipped")
+	if c == nil {
+		t.Fatalf("expected a public_access_flipped change, got %+v", changes)
+	}
+	if c.Severity != diff.SeverityCritical {
+		t.Errorf("expected flipping to public to be critical severity, got %s", c.Severity)
+	}
+}
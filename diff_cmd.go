@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/diff"
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// runDiff implements "scanner diff <old> <new>": load two prior scan
+// outputs and report configuration drift between them.
+func runDiff(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "emit changes as JSON instead of a human-readable report")
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if len(rest) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: scanner diff [-json] <old.json> <new.json>")
+		os.Exit(2)
+	}
+
+	prev, err := loadBucketInfos(rest[0])
+	if err != nil {
+		log.Fatalf("failed to load %s: %v", rest[0], err)
+	}
+	curr, err := loadBucketInfos(rest[1])
+	if err != nil {
+		log.Fatalf("failed to load %s: %v", rest[1], err)
+	}
+
+	changes := diff.Diff(prev, curr)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(changes); err != nil {
+			log.Fatalf("failed to encode changes: %v", err)
+		}
+		return
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("no drift detected")
+		return
+	}
+	for _, c := range changes {
+		fmt.Printf("[%s] %s: %s", c.Severity, c.Bucket, c.Description)
+		if c.Before != "" || c.After != "" {
+			fmt.Printf(" (before=%q after=%q)", c.Before, c.After)
+		}
+		fmt.Println()
+	}
+}
+
+// loadBucketInfos reads a prior scan's output file, accepting either a
+// single JSON array (the legacy bucket_info.json shape) or NDJSON (one
+// BucketInfo per line, gzip not supported here since diff inputs are
+// expected to already be on local disk).
+func loadBucketInfos(path string) ([]scanner.BucketInfo, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var asArray []scanner.BucketInfo
+	if err := json.NewDecoder(file).Decode(&asArray); err == nil {
+		return asArray, nil
+	}
+
+	if _, err := file.Seek(0, 0); err != nil {
+		return nil, err
+	}
+
+	var infos []scanner.BucketInfo
+	scan := bufio.NewScanner(file)
+	scan.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scan.Scan() {
+		line := scan.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var info scanner.BucketInfo
+		if err := json.Unmarshal(line, &info); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		infos = append(infos, info)
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
@@ -0,0 +1,32 @@
+package findings
+
+import "github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+
+// Engine runs a set of registered Rules against scanned buckets.
+type Engine struct {
+	rules []Rule
+}
+
+// NewEngine returns an Engine seeded with rules. Pass DefaultRules() to get
+// the built-in rule pack, or nil/empty to start from scratch.
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: append([]Rule(nil), rules...)}
+}
+
+// Register adds a Rule to the engine, allowing callers to extend the
+// built-in rule pack with custom checks.
+func (e *Engine) Register(rule Rule) {
+	e.rules = append(e.rules, rule)
+}
+
+// Run evaluates every registered rule against every bucket and returns the
+// combined findings.
+func (e *Engine) Run(infos []scanner.BucketInfo) []Finding {
+	var all []Finding
+	for _, info := range infos {
+		for _, rule := range e.rules {
+			all = append(all, rule.Check(info)...)
+		}
+	}
+	return all
+}
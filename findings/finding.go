@@ -0,0 +1,41 @@
+// Package findings runs a pluggable set of security rules against scanned
+// bucket configuration and emits structured findings.
+package findings
+
+import "github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+
+// Severity ranks how urgently a Finding should be remediated.
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+)
+
+// Finding is a single rule violation surfaced for one bucket.
+type Finding struct {
+	ID          string   `json:"id"`
+	Severity    Severity `json:"severity"`
+	Bucket      string   `json:"bucket"`
+	ResourceARN string   `json:"resourceArn"`
+	Title       string   `json:"title"`
+	Remediation string   `json:"remediation"`
+}
+
+func arn(bucket string) string {
+	return "arn:aws:s3:::" + bucket
+}
+
+// Rule inspects a single bucket's configuration and returns zero or more
+// Findings. Implementations should be stateless and safe to reuse across
+// buckets.
+type Rule interface {
+	// ID is a short, stable identifier for this rule, used as Finding.ID
+	// (e.g. "S3-PUBLIC-ACL"). A rule that reports more than one kind of
+	// finding may give the extra kinds their own IDs.
+	ID() string
+	// Check evaluates info and returns any findings it produces.
+	Check(info scanner.BucketInfo) []Finding
+}
@@ -0,0 +1,64 @@
+package findings
+
+// ocsfFinding models a minimal subset of OCSF's Detection Finding event
+// class (class_uid 2004) sufficient for ingestion by SIEMs that understand
+// OCSF, without pulling in a full schema library.
+type ocsfFinding struct {
+	ClassUID    int             `json:"class_uid"`
+	ClassName   string          `json:"class_name"`
+	CategoryUID int             `json:"category_uid"`
+	ActivityID  int             `json:"activity_id"`
+	SeverityID  int             `json:"severity_id"`
+	Severity    string          `json:"severity"`
+	Message     string          `json:"message"`
+	Finding     ocsfFindingInfo `json:"finding_info"`
+	Resources   []ocsfResource  `json:"resources"`
+}
+
+type ocsfFindingInfo struct {
+	UID   string `json:"uid"`
+	Title string `json:"title"`
+	Desc  string `json:"desc"`
+}
+
+type ocsfResource struct {
+	UID  string `json:"uid"`
+	Type string `json:"type"`
+}
+
+// ToOCSF converts findings into OCSF Detection Finding events.
+func ToOCSF(all []Finding) []ocsfFinding {
+	events := make([]ocsfFinding, 0, len(all))
+	for _, f := range all {
+		severityID, severity := ocsfSeverity(f.Severity)
+		events = append(events, ocsfFinding{
+			ClassUID:    2004,
+			ClassName:   "Detection Finding",
+			CategoryUID: 2,
+			ActivityID:  1,
+			SeverityID:  severityID,
+			Severity:    severity,
+			Message:     f.Title,
+			Finding: ocsfFindingInfo{
+				UID:   f.ID,
+				Title: f.Title,
+				Desc:  f.Remediation,
+			},
+			Resources: []ocsfResource{{UID: f.ResourceARN, Type: "s3-bucket"}},
+		})
+	}
+	return events
+}
+
+func ocsfSeverity(sev Severity) (int, string) {
+	switch sev {
+	case SeverityCritical:
+		return 5, "Critical"
+	case SeverityHigh:
+		return 4, "High"
+	case SeverityMedium:
+		return 3, "Medium"
+	default:
+		return 2, "Low"
+	}
+}
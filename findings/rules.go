@@ -0,0 +1,309 @@
+package findings
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// DefaultRules returns the built-in rule pack.
+func DefaultRules() []Rule {
+	return []Rule{
+		publicACLRule{},
+		publicPolicyStatusRule{},
+		openPolicyRule{},
+		encryptionRule{},
+		versioningRule{},
+		loggingRule{},
+		replicationComplianceRule{},
+		corsWildcardRule{},
+	}
+}
+
+const (
+	granteeAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	granteeAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// publicACLRule flags bucket ACL grants to the AllUsers or
+// AuthenticatedUsers predefined groups.
+type publicACLRule struct{}
+
+func (publicACLRule) ID() string { return "S3-PUBLIC-ACL" }
+
+func (r publicACLRule) Check(info scanner.BucketInfo) []Finding {
+	if info.ACL == nil {
+		return nil
+	}
+	for _, grant := range info.ACL.Grants {
+		if grant.Grantee == nil {
+			continue
+		}
+		uri := aws.ToString(grant.Grantee.URI)
+		if uri == granteeAllUsers || uri == granteeAuthenticatedUsers {
+			return []Finding{{
+				ID:          r.ID(),
+				Severity:    SeverityCritical,
+				Bucket:      info.Name,
+				ResourceARN: arn(info.Name),
+				Title:       "bucket ACL grants access to " + uri,
+				Remediation: "remove the AllUsers/AuthenticatedUsers grant and use IAM or a bucket policy scoped to specific principals instead",
+			}}
+		}
+	}
+	return nil
+}
+
+// publicPolicyStatusRule flags buckets where S3's own public-access
+// evaluation (GetBucketPolicyStatus) reports the bucket as public.
+type publicPolicyStatusRule struct{}
+
+func (publicPolicyStatusRule) ID() string { return "S3-PUBLIC-POLICY-STATUS" }
+
+func (r publicPolicyStatusRule) Check(info scanner.BucketInfo) []Finding {
+	if info.PolicyStatus == nil || info.PolicyStatus.PolicyStatus == nil {
+		return nil
+	}
+	if aws.ToBool(info.PolicyStatus.PolicyStatus.IsPublic) {
+		return []Finding{{
+			ID:          r.ID(),
+			Severity:    SeverityCritical,
+			Bucket:      info.Name,
+			ResourceARN: arn(info.Name),
+			Title:       "AWS reports this bucket's policy as public",
+			Remediation: "enable S3 Block Public Access or tighten the bucket policy until GetBucketPolicyStatus reports IsPublic=false",
+		}}
+	}
+	return nil
+}
+
+// policyDocument is a minimal, loosely-typed view of an IAM policy document
+// sufficient to evaluate statement-level rules without depending on the
+// full IAM policy grammar.
+type policyDocument struct {
+	Statement []policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Effect    string                                `json:"Effect"`
+	Principal json.RawMessage                       `json:"Principal"`
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+}
+
+func (s policyStatement) principalIsWildcard() bool {
+	var str string
+	if err := json.Unmarshal(s.Principal, &str); err == nil {
+		return str == "*"
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(s.Principal, &obj); err == nil {
+		if principal, ok := obj["AWS"].(string); ok {
+			return principal == "*"
+		}
+	}
+	return false
+}
+
+func (s policyStatement) hasSourceRestriction() bool {
+	for _, conds := range s.Condition {
+		for condKey := range conds {
+			if condKey == "aws:SourceIp" || condKey == "aws:SourceVpce" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// openPolicyRule flags bucket policy statements that allow Principal: "*"
+// without a restricting aws:SourceIp/aws:SourceVpce condition.
+type openPolicyRule struct{}
+
+func (openPolicyRule) ID() string { return "S3-OPEN-POLICY-PRINCIPAL" }
+
+func (r openPolicyRule) Check(info scanner.BucketInfo) []Finding {
+	if info.Policy == nil || info.Policy.Policy == nil {
+		return nil
+	}
+	var doc policyDocument
+	if err := json.Unmarshal([]byte(aws.ToString(info.Policy.Policy)), &doc); err != nil {
+		return nil
+	}
+	for _, stmt := range doc.Statement {
+		if !strings.EqualFold(stmt.Effect, "Allow") {
+			continue
+		}
+		if stmt.principalIsWildcard() && !stmt.hasSourceRestriction() {
+			return []Finding{{
+				ID:          r.ID(),
+				Severity:    SeverityCritical,
+				Bucket:      info.Name,
+				ResourceARN: arn(info.Name),
+				Title:       "bucket policy allows Principal \"*\" with no aws:SourceIp/aws:SourceVpce condition",
+				Remediation: "scope the statement to specific principals, or add an aws:SourceIp/aws:SourceVpce condition",
+			}}
+		}
+	}
+	return nil
+}
+
+// encryptionRule flags buckets with no default encryption configured, or
+// default encryption that isn't KMS-backed.
+type encryptionRule struct{}
+
+func (encryptionRule) ID() string { return "S3-WEAK-ENCRYPTION" }
+
+func (r encryptionRule) Check(info scanner.BucketInfo) []Finding {
+	if info.EncryptionConfig == nil || info.EncryptionConfig.ServerSideEncryptionConfiguration == nil ||
+		len(info.EncryptionConfig.ServerSideEncryptionConfiguration.Rules) == 0 {
+		return []Finding{{
+			ID:          r.ID(),
+			Severity:    SeverityHigh,
+			Bucket:      info.Name,
+			ResourceARN: arn(info.Name),
+			Title:       "bucket has no default encryption configured",
+			Remediation: "enable default encryption (preferably SSE-KMS with a customer managed key)",
+		}}
+	}
+	for _, rule := range info.EncryptionConfig.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault == nil {
+			continue
+		}
+		if algo := rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm; algo != "aws:kms" && algo != "aws:kms:dsse" {
+			return []Finding{{
+				ID:          r.ID(),
+				Severity:    SeverityMedium,
+				Bucket:      info.Name,
+				ResourceARN: arn(info.Name),
+				Title:       "default encryption uses " + string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm) + " instead of aws:kms",
+				Remediation: "switch default encryption to SSE-KMS with a customer managed key for auditability and key rotation control",
+			}}
+		}
+	}
+	return nil
+}
+
+// versioningRule flags buckets with versioning or MFA delete disabled.
+type versioningRule struct{}
+
+func (versioningRule) ID() string { return "S3-VERSIONING-DISABLED" }
+
+func (r versioningRule) Check(info scanner.BucketInfo) []Finding {
+	var findings []Finding
+	if info.VersioningConfig == nil || info.VersioningConfig.Status != "Enabled" {
+		findings = append(findings, Finding{
+			ID:          r.ID(),
+			Severity:    SeverityMedium,
+			Bucket:      info.Name,
+			ResourceARN: arn(info.Name),
+			Title:       "bucket versioning is not enabled",
+			Remediation: "enable versioning to protect against accidental overwrite/delete",
+		})
+	}
+	if info.VersioningConfig != nil && info.VersioningConfig.MFADelete != "Enabled" {
+		findings = append(findings, Finding{
+			ID:          "S3-MFA-DELETE-DISABLED",
+			Severity:    SeverityLow,
+			Bucket:      info.Name,
+			ResourceARN: arn(info.Name),
+			Title:       "MFA delete is not enabled",
+			Remediation: "enable MFA delete to require multi-factor auth for permanent object/version deletion",
+		})
+	}
+	return findings
+}
+
+// loggingRule flags buckets with no access logging configured.
+type loggingRule struct{}
+
+func (loggingRule) ID() string { return "S3-LOGGING-DISABLED" }
+
+func (r loggingRule) Check(info scanner.BucketInfo) []Finding {
+	if info.LoggingConfig == nil || info.LoggingConfig.LoggingEnabled == nil {
+		return []Finding{{
+			ID:          r.ID(),
+			Severity:    SeverityLow,
+			Bucket:      info.Name,
+			ResourceARN: arn(info.Name),
+			Title:       "bucket access logging is not enabled",
+			Remediation: "enable server access logging (or S3 Storage Lens / CloudTrail data events) for audit visibility",
+		}}
+	}
+	return nil
+}
+
+// replicationComplianceRule flags buckets tagged compliance=* with
+// replication disabled.
+type replicationComplianceRule struct{}
+
+func (replicationComplianceRule) ID() string { return "S3-REPLICATION-REQUIRED" }
+
+func (r replicationComplianceRule) Check(info scanner.BucketInfo) []Finding {
+	if !taggedCompliance(info) {
+		return nil
+	}
+	if info.ReplicationConfig == nil || info.ReplicationConfig.ReplicationConfiguration == nil ||
+		len(info.ReplicationConfig.ReplicationConfiguration.Rules) == 0 {
+		return []Finding{{
+			ID:          r.ID(),
+			Severity:    SeverityHigh,
+			Bucket:      info.Name,
+			ResourceARN: arn(info.Name),
+			Title:       "bucket is tagged compliance=* but has no replication rule configured",
+			Remediation: "configure cross-region replication to satisfy the bucket's compliance tag",
+		}}
+	}
+	return nil
+}
+
+func taggedCompliance(info scanner.BucketInfo) bool {
+	if info.TaggingConfig == nil {
+		return false
+	}
+	for _, tag := range info.TaggingConfig.TagSet {
+		if aws.ToString(tag.Key) == "compliance" && aws.ToString(tag.Value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// corsWildcardRule flags CORS rules that allow any origin ("*") together
+// with a state-changing method, the closest bucket-level analog to a
+// browser CORS policy that leaks credentialed requests cross-origin.
+type corsWildcardRule struct{}
+
+func (corsWildcardRule) ID() string { return "S3-CORS-WILDCARD-ORIGIN" }
+
+func (r corsWildcardRule) Check(info scanner.BucketInfo) []Finding {
+	if info.CORSConfig == nil {
+		return nil
+	}
+	for _, rule := range info.CORSConfig.CORSRules {
+		hasWildcardOrigin := false
+		for _, origin := range rule.AllowedOrigins {
+			if origin == "*" {
+				hasWildcardOrigin = true
+				break
+			}
+		}
+		if !hasWildcardOrigin {
+			continue
+		}
+		for _, method := range rule.AllowedMethods {
+			if method == "PUT" || method == "POST" || method == "DELETE" {
+				return []Finding{{
+					ID:          r.ID(),
+					Severity:    SeverityMedium,
+					Bucket:      info.Name,
+					ResourceARN: arn(info.Name),
+					Title:       "CORS rule allows origin \"*\" with " + method + ", permitting any site to write/delete objects",
+					Remediation: "scope AllowedOrigins to known origins instead of \"*\" when AllowedMethods includes state-changing verbs",
+				}}
+			}
+		}
+	}
+	return nil
+}
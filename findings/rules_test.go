@@ -0,0 +1,179 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+func assertFindingIDs(t *testing.T, findings []Finding, wantIDs ...string) {
+	t.Helper()
+	if len(findings) != len(wantIDs) {
+		t.Fatalf("got %d finding(s) %+v, want %d (%v)", len(findings), findings, len(wantIDs), wantIDs)
+	}
+	for i, want := range wantIDs {
+		if findings[i].ID != want {
+			t.Errorf("finding %d: got ID %q, want %q", i, findings[i].ID, want)
+		}
+	}
+}
+
+func TestPublicACLRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		acl     *s3.GetBucketAclOutput
+		wantIDs []string
+	}{
+		{
+			name:    "AllUsers grant is flagged",
+			acl:     &s3.GetBucketAclOutput{Grants: []types.Grant{{Grantee: &types.Grantee{URI: aws.String(granteeAllUsers)}}}},
+			wantIDs: []string{"S3-PUBLIC-ACL"},
+		},
+		{
+			name:    "AuthenticatedUsers grant is flagged",
+			acl:     &s3.GetBucketAclOutput{Grants: []types.Grant{{Grantee: &types.Grantee{URI: aws.String(granteeAuthenticatedUsers)}}}},
+			wantIDs: []string{"S3-PUBLIC-ACL"},
+		},
+		{
+			name: "grant to a specific canonical user is not flagged",
+			acl:  &s3.GetBucketAclOutput{Grants: []types.Grant{{Grantee: &types.Grantee{ID: aws.String("canonical-user-id")}}}},
+		},
+		{
+			name: "no ACL collected yet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := scanner.BucketInfo{Name: "test-bucket", ACL: tt.acl}
+			assertFindingIDs(t, publicACLRule{}.Check(info), tt.wantIDs...)
+		})
+	}
+}
+
+func TestOpenPolicyRule(t *testing.T) {
+	policy := func(doc string) *s3.GetBucketPolicyOutput {
+		return &s3.GetBucketPolicyOutput{Policy: aws.String(doc)}
+	}
+
+	tests := []struct {
+		name    string
+		policy  *s3.GetBucketPolicyOutput
+		wantIDs []string
+	}{
+		{
+			name:    "wildcard principal with no condition is flagged",
+			policy:  policy(`{"Statement":[{"Effect":"Allow","Principal":"*"}]}`),
+			wantIDs: []string{"S3-OPEN-POLICY-PRINCIPAL"},
+		},
+		{
+			name:    "wildcard AWS principal with no condition is flagged",
+			policy:  policy(`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"*"}}]}`),
+			wantIDs: []string{"S3-OPEN-POLICY-PRINCIPAL"},
+		},
+		{
+			name:   "wildcard principal restricted by aws:SourceIp is not flagged",
+			policy: policy(`{"Statement":[{"Effect":"Allow","Principal":"*","Condition":{"IpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}]}`),
+		},
+		{
+			name:   "Deny statement with wildcard principal is not flagged",
+			policy: policy(`{"Statement":[{"Effect":"Deny","Principal":"*"}]}`),
+		},
+		{
+			name:   "scoped principal is not flagged",
+			policy: policy(`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111122223333:root"}}]}`),
+		},
+		{
+			name: "no policy collected yet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := scanner.BucketInfo{Name: "test-bucket", Policy: tt.policy}
+			assertFindingIDs(t, openPolicyRule{}.Check(info), tt.wantIDs...)
+		})
+	}
+}
+
+func TestEncryptionRule(t *testing.T) {
+	sseConfig := func(algo types.ServerSideEncryption) *s3.GetBucketEncryptionOutput {
+		return &s3.GetBucketEncryptionOutput{
+			ServerSideEncryptionConfiguration: &types.ServerSideEncryptionConfiguration{
+				Rules: []types.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{SSEAlgorithm: algo},
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		encryption *s3.GetBucketEncryptionOutput
+		wantIDs    []string
+	}{
+		{
+			name:       "no encryption configured is flagged",
+			encryption: nil,
+			wantIDs:    []string{"S3-WEAK-ENCRYPTION"},
+		},
+		{
+			name:       "SSE-S3 is flagged as weak",
+			encryption: sseConfig(types.ServerSideEncryptionAes256),
+			wantIDs:    []string{"S3-WEAK-ENCRYPTION"},
+		},
+		{
+			name:       "SSE-KMS is not flagged",
+			encryption: sseConfig(types.ServerSideEncryptionAwsKms),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := scanner.BucketInfo{Name: "test-bucket", EncryptionConfig: tt.encryption}
+			assertFindingIDs(t, encryptionRule{}.Check(info), tt.wantIDs...)
+		})
+	}
+}
+
+func TestVersioningRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		versioning *s3.GetBucketVersioningOutput
+		wantIDs    []string
+	}{
+		{
+			name:       "versioning not configured is flagged",
+			versioning: nil,
+			wantIDs:    []string{"S3-VERSIONING-DISABLED"},
+		},
+		{
+			name:       "versioning suspended is flagged",
+			versioning: &s3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusSuspended},
+			wantIDs:    []string{"S3-VERSIONING-DISABLED", "S3-MFA-DELETE-DISABLED"},
+		},
+		{
+			name: "versioning and MFA delete both enabled is clean",
+			versioning: &s3.GetBucketVersioningOutput{
+				Status:    types.BucketVersioningStatusEnabled,
+				MFADelete: types.MFADeleteStatusEnabled,
+			},
+		},
+		{
+			name:       "versioning enabled but MFA delete not is flagged",
+			versioning: &s3.GetBucketVersioningOutput{Status: types.BucketVersioningStatusEnabled},
+			wantIDs:    []string{"S3-MFA-DELETE-DISABLED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := scanner.BucketInfo{Name: "test-bucket", VersioningConfig: tt.versioning}
+			assertFindingIDs(t, versioningRule{}.Check(info), tt.wantIDs...)
+		})
+	}
+}
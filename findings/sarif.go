@@ -0,0 +1,97 @@
+package findings
+
+// SARIF models the minimal subset of the SARIF 2.1.0 schema needed to
+// publish findings to GitHub code scanning.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// ToSARIF converts findings into a SARIF 2.1.0 log for GitHub code scanning.
+func ToSARIF(all []Finding) sarifLog {
+	rulesByID := map[string]sarifRule{}
+	results := make([]sarifResult, 0, len(all))
+	for _, f := range all {
+		rulesByID[f.ID] = sarifRule{ID: f.ID, Name: f.ID}
+		results = append(results, sarifResult{
+			RuleID: f.ID,
+			Level:  sarifLevel(f.Severity),
+			Message: sarifMessage{
+				Text: f.Title + " — " + f.Remediation,
+			},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.ResourceARN},
+				},
+			}},
+		})
+	}
+
+	rules := make([]sarifRule, 0, len(rulesByID))
+	for _, rule := range rulesByID {
+		rules = append(rules, rule)
+	}
+
+	return sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "s3-bucket-scanner", Rules: rules}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifLevel(sev Severity) string {
+	switch sev {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// ndjsonWriter writes one JSON object per line, flushing after every bucket
+// so a consumer tailing the output sees results as the scan progresses.
+type ndjsonWriter struct {
+	sink io.WriteCloser
+	gz   *gzip.Writer
+	enc  *json.Encoder
+}
+
+func newNDJSONWriter(sink io.WriteCloser, compress bool) *ndjsonWriter {
+	w := &ndjsonWriter{sink: sink}
+	var out io.Writer = sink
+	if compress {
+		w.gz = gzip.NewWriter(sink)
+		out = w.gz
+	}
+	w.enc = json.NewEncoder(out)
+	return w
+}
+
+func (w *ndjsonWriter) WriteBucket(info scanner.BucketInfo) error {
+	if err := w.enc.Encode(info); err != nil {
+		return err
+	}
+	if w.gz != nil {
+		return w.gz.Flush()
+	}
+	return nil
+}
+
+func (w *ndjsonWriter) Close() error {
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			w.sink.Close()
+			return err
+		}
+	}
+	return w.sink.Close()
+}
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// openSink resolves dest into a streaming io.WriteCloser. Supported schemes
+// are file:// (or a bare path) and s3://bucket/key, which uploads via the S3
+// manager's multipart uploader so results can be produced without ever
+// landing on local disk (e.g. from Lambda or a scheduled ECS task).
+func openSink(dest string) (io.WriteCloser, error) {
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme == "" || u.Scheme == "file" {
+		path := dest
+		if u != nil && u.Scheme == "file" {
+			path = u.Host + u.Path
+		}
+		return os.Create(path)
+	}
+
+	if u.Scheme != "s3" {
+		return nil, fmt.Errorf("unsupported output scheme %q", u.Scheme)
+	}
+
+	bucket := u.Host
+	key := strings.TrimPrefix(u.Path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("s3 output URL must be s3://bucket/key, got %q", dest)
+	}
+
+	return newS3Sink(bucket, key)
+}
+
+// s3Sink streams writes into an S3 object via an io.Pipe, so the uploader's
+// multipart parts are filled as the scan progresses rather than buffering
+// the whole object first.
+type s3Sink struct {
+	pw    *io.PipeWriter
+	done  chan struct{}
+	upErr error
+	once  sync.Once
+}
+
+func newS3Sink(bucket, key string) (*s3Sink, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(cfg)
+	uploader := manager.NewUploader(client)
+
+	pr, pw := io.Pipe()
+	sink := &s3Sink{pw: pw, done: make(chan struct{})}
+
+	go func() {
+		defer close(sink.done)
+		_, err := uploader.Upload(context.Background(), &s3.PutObjectInput{
+			Bucket: &bucket,
+			Key:    &key,
+			Body:   pr,
+		})
+		sink.upErr = err
+		// Drain the reader on upload failure so Write doesn't block forever.
+		io.Copy(io.Discard, pr)
+	}()
+
+	return sink, nil
+}
+
+func (s *s3Sink) Write(p []byte) (int, error) {
+	return s.pw.Write(p)
+}
+
+func (s *s3Sink) Close() error {
+	var err error
+	s.once.Do(func() {
+		s.pw.Close()
+		<-s.done
+		err = s.upErr
+	})
+	return err
+}
@@ -0,0 +1,57 @@
+// Package output streams scan results to a destination as they're
+// collected, instead of buffering every bucket in memory before a single
+// write.
+package output
+
+import (
+	"fmt"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// Format selects the on-disk encoding for a Writer.
+type Format string
+
+const (
+	// FormatNDJSON writes one JSON-encoded bucket per line, flushed as each
+	// bucket is written.
+	FormatNDJSON Format = "ndjson"
+	// FormatParquet writes a flattened Parquet file for Athena/Glue
+	// querying.
+	FormatParquet Format = "parquet"
+)
+
+// Options configures how a Writer encodes and compresses output.
+type Options struct {
+	Format Format
+	// Gzip compresses NDJSON output. Ignored for FormatParquet, which is
+	// already columnar-compressed.
+	Gzip bool
+}
+
+// Writer streams BucketInfo records to a destination one at a time. Callers
+// must call Close when done to flush buffers and finalize the destination
+// (including completing any in-flight S3 multipart upload).
+type Writer interface {
+	WriteBucket(info scanner.BucketInfo) error
+	Close() error
+}
+
+// New returns a Writer for dest (a file path, file:// URL, or s3://bucket/key
+// URL) using opts.
+func New(dest string, opts Options) (Writer, error) {
+	sink, err := openSink(dest)
+	if err != nil {
+		return nil, fmt.Errorf("open sink %q: %w", dest, err)
+	}
+
+	switch opts.Format {
+	case "", FormatNDJSON:
+		return newNDJSONWriter(sink, opts.Gzip), nil
+	case FormatParquet:
+		return newParquetWriter(sink), nil
+	default:
+		sink.Close()
+		return nil, fmt.Errorf("unknown output format %q", opts.Format)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/findings"
+	"github.com/optiowl-cloud/s3-bucket-scanner/output"
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// runScan implements the (default) "scan" subcommand: scan every configured
+// account/region and stream results to an output.Writer, optionally running
+// the findings engine over the results.
+func runScan(args []string) {
+	fs := flag.NewFlagSet("scan", flag.ExitOnError)
+	var (
+		profiles       = fs.String("profiles", "", "comma-separated AWS profiles to scan (default credential chain if empty)")
+		roleARN        = fs.String("role-arn", "", "role ARN to assume via STS for every profile")
+		externalID     = fs.String("external-id", "", "external ID to pass when assuming --role-arn")
+		regions        = fs.String("regions", "", "comma-separated regions to restrict the scan to (every bucket is still collected in its own home region; default scans every region)")
+		exclude        = fs.String("exclude", os.Getenv("EXCLUDED_BUCKETS"), "comma-separated bucket names to skip")
+		concurrency    = fs.Int("concurrency", scanner.DefaultConcurrency, "max buckets collected in parallel")
+		timeout        = fs.Duration("call-timeout", scanner.DefaultCallTimeout, "per-call timeout")
+		maxRetries     = fs.Int("max-retries", scanner.DefaultMaxRetries, "max retries for throttled calls")
+		out            = fs.String("output", "bucket_info.ndjson", "output destination: a file path, file://path, or s3://bucket/key")
+		outputFormat   = fs.String("output-format", "ndjson", "output format: ndjson or parquet")
+		outputGzip     = fs.Bool("output-gzip", false, "gzip-compress ndjson output")
+		auditObjects   = fs.Bool("audit-objects", false, "paginate objects per bucket and audit encryption/storage class")
+		objectPrefix   = fs.String("object-prefix", "", "only audit objects under this key prefix")
+		maxObjects     = fs.Int("max-objects-per-bucket", 0, "cap objects inspected per bucket (0 = no cap)")
+		sampleRate     = fs.Int("object-sample-rate", 0, "inspect only every Nth object (0 or 1 = every object)")
+		findingsOutput = fs.String("findings-output", "", "write security findings to this path (skipped if empty)")
+		findingsFormat = fs.String("findings-format", "json", "findings output format: json, sarif, or ocsf")
+		endpointURL    = fs.String("endpoint-url", os.Getenv("AWS_ENDPOINT_URL"), "custom S3-compatible endpoint (MinIO, SeaweedFS, R2, Ceph RGW) instead of AWS")
+		endpointRegion = fs.String("region", os.Getenv("AWS_REGION"), "region to pass to the client (most S3-compatible endpoints ignore the value but require one)")
+		endpointFlavor = fs.String("endpoint-flavor", os.Getenv("S3_ENDPOINT_FLAVOR"), "endpoint implementation, for skipping unsupported calls: aws, minio, seaweedfs, r2, or ceph")
+		pathStyle      = fs.Bool("path-style", false, "use path-style bucket addressing (required by most non-AWS endpoints)")
+		disableSSL     = fs.Bool("disable-ssl", false, "use http instead of https for --endpoint-url")
+	)
+	fs.Parse(args)
+
+	start := time.Now()
+
+	cfg := scanner.Config{
+		Concurrency: *concurrency,
+		CallTimeout: *timeout,
+		MaxRetries:  *maxRetries,
+		Objects: scanner.ObjectConfig{
+			Enabled:    *auditObjects,
+			Prefix:     *objectPrefix,
+			MaxObjects: *maxObjects,
+			SampleRate: *sampleRate,
+		},
+		Endpoint: scanner.Endpoint{
+			URL:        *endpointURL,
+			Region:     *endpointRegion,
+			Flavor:     scanner.Flavor(*endpointFlavor),
+			PathStyle:  *pathStyle,
+			DisableSSL: *disableSSL,
+		},
+	}
+	for _, p := range splitCSV(*profiles) {
+		cfg.Accounts = append(cfg.Accounts, scanner.Account{Profile: p, RoleARN: *roleARN, ExternalID: *externalID})
+	}
+	cfg.Regions = splitCSV(*regions)
+
+	s := scanner.New(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	writer, err := output.New(*out, output.Options{Format: output.Format(*outputFormat), Gzip: *outputGzip})
+	if err != nil {
+		log.Fatalf("failed to open output %q: %v", *out, err)
+	}
+
+	excluded := splitCSV(*exclude)
+	needFindings := *findingsOutput != ""
+
+	wantedRegions := make(map[string]bool, len(cfg.Regions))
+	for _, r := range cfg.Regions {
+		wantedRegions[r] = true
+	}
+
+	var (
+		mu          sync.Mutex
+		written     int
+		forFindings []scanner.BucketInfo
+	)
+
+	errs, err := s.ScanStream(ctx, func(info scanner.BucketInfo) {
+		if isExcluded(info.Name, excluded) {
+			return
+		}
+		if len(wantedRegions) > 0 && !wantedRegions[info.Region] {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if err := writer.WriteBucket(info); err != nil {
+			log.Printf("warning: failed to write bucket %s: %v", info.Name, err)
+			return
+		}
+		written++
+		if needFindings {
+			forFindings = append(forFindings, info)
+		}
+	})
+	if err != nil {
+		log.Fatalf("scan failed: %v", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Fatalf("failed to finalize output %q: %v", *out, err)
+	}
+
+	for _, e := range errs {
+		log.Printf("warning: %s", e.Error())
+	}
+
+	fmt.Printf(
+		"scanned %d bucket(s) in %s, %d error(s); results written to %s\n",
+		written, time.Since(start), len(errs), *out,
+	)
+
+	if needFindings {
+		if err := writeFindings(forFindings, *findingsOutput, *findingsFormat); err != nil {
+			log.Fatalf("failed to write findings: %v", err)
+		}
+	}
+}
+
+func writeFindings(infos []scanner.BucketInfo, path, format string) error {
+	engine := findings.NewEngine(findings.DefaultRules()...)
+	all := engine.Run(infos)
+
+	var payload any
+	switch format {
+	case "json":
+		payload = all
+	case "sarif":
+		payload = findings.ToSARIF(all)
+	case "ocsf":
+		payload = findings.ToOCSF(all)
+	default:
+		return fmt.Errorf("unknown findings format %q (want json, sarif, or ocsf)", format)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create findings output: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return fmt.Errorf("encode findings: %w", err)
+	}
+
+	fmt.Printf("%d finding(s) written to %s (%s)\n", len(all), path, format)
+	return nil
+}
+
+func splitCSV(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func isExcluded(name string, excluded []string) bool {
+	for _, e := range excluded {
+		if e == name {
+			return true
+		}
+	}
+	return false
+}
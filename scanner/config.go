@@ -0,0 +1,76 @@
+package scanner
+
+import "time"
+
+// Account identifies a set of credentials to scan with: a named profile
+// from the shared config/credentials files, optionally assuming a role via
+// STS before listing buckets.
+type Account struct {
+	// Profile is the AWS named profile to load, e.g. from AWS_PROFILE or
+	// ~/.aws/config. Empty uses the default credential chain.
+	Profile string
+	// RoleARN, if set, is assumed via STS AssumeRole after loading Profile's
+	// credentials.
+	RoleARN string
+	// ExternalID is passed to AssumeRole when RoleARN is set.
+	ExternalID string
+}
+
+// Config controls how a Scanner discovers accounts/regions and how
+// aggressively it fans out work against the S3 API.
+type Config struct {
+	// Accounts to scan. A zero-value Account (the default credential chain)
+	// is used if this is empty.
+	Accounts []Account
+	// Regions restricts the scan to buckets whose home region (as reported
+	// by GetBucketLocation) is in this list; every bucket is still collected
+	// in its own home region regardless of this setting. Leave empty to
+	// scan every bucket visible to each account, in whatever region it
+	// actually lives in. The filter is applied by Scan; ScanStream passes
+	// every collected bucket to its callback.
+	Regions []string
+	// Concurrency bounds how many buckets are collected in parallel across
+	// all accounts/regions. Defaults to DefaultConcurrency.
+	Concurrency int
+	// CallTimeout bounds each individual S3 API call. Defaults to
+	// DefaultCallTimeout.
+	CallTimeout time.Duration
+	// MaxRetries bounds retry attempts for throttled calls. Defaults to
+	// DefaultMaxRetries.
+	MaxRetries int
+	// Objects configures the per-object inventory pass. Leave at its zero
+	// value to skip object-level collection entirely.
+	Objects ObjectConfig
+	// Endpoint points the scanner at a custom S3-compatible endpoint
+	// (MinIO, SeaweedFS, R2, Ceph RGW) instead of real AWS S3. Leave at its
+	// zero value to use AWS's default endpoint resolution.
+	Endpoint Endpoint
+}
+
+const (
+	// DefaultConcurrency is the worker pool size used when Config.Concurrency
+	// is unset.
+	DefaultConcurrency = 10
+	// DefaultCallTimeout bounds a single S3 API call when Config.CallTimeout
+	// is unset.
+	DefaultCallTimeout = 30 * time.Second
+	// DefaultMaxRetries bounds retry attempts for throttled calls when
+	// Config.MaxRetries is unset.
+	DefaultMaxRetries = 5
+)
+
+func (c Config) withDefaults() Config {
+	if c.Concurrency <= 0 {
+		c.Concurrency = DefaultConcurrency
+	}
+	if c.CallTimeout <= 0 {
+		c.CallTimeout = DefaultCallTimeout
+	}
+	if c.MaxRetries <= 0 {
+		c.MaxRetries = DefaultMaxRetries
+	}
+	if len(c.Accounts) == 0 {
+		c.Accounts = []Account{{}}
+	}
+	return c
+}
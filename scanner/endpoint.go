@@ -0,0 +1,138 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	smithyendpoints "github.com/aws/smithy-go/endpoints"
+)
+
+// Flavor identifies an S3-compatible implementation so Scanner can skip API
+// calls it doesn't support instead of failing the whole bucket.
+type Flavor string
+
+const (
+	// FlavorAWS is real AWS S3, which supports every call BucketInfo makes.
+	FlavorAWS       Flavor = "aws"
+	FlavorMinIO     Flavor = "minio"
+	FlavorSeaweedFS Flavor = "seaweedfs"
+	FlavorR2        Flavor = "r2"
+	FlavorCeph      Flavor = "ceph"
+)
+
+// Capability names a bucket subresource call that not every S3-compatible
+// implementation supports.
+type Capability string
+
+const (
+	CapAccelerate         Capability = "accelerate"
+	CapAnalytics          Capability = "analytics"
+	CapIntelligentTiering Capability = "intelligent-tiering"
+	CapInventory          Capability = "inventory"
+	CapMetrics            Capability = "metrics"
+)
+
+// capabilityMatrix records, per Flavor, which Capabilities are known to be
+// unsupported. A Capability absent from a Flavor's entry (or a Flavor absent
+// from the matrix entirely, e.g. the empty Flavor meaning "real AWS") is
+// assumed supported.
+var capabilityMatrix = map[Flavor]map[Capability]bool{
+	// SeaweedFS doesn't implement these bucket subresources.
+	FlavorSeaweedFS: {
+		CapAccelerate:         false,
+		CapAnalytics:          false,
+		CapIntelligentTiering: false,
+		CapInventory:          false,
+		CapMetrics:            false,
+	},
+	// MinIO doesn't implement accelerate, analytics, intelligent-tiering, or
+	// inventory configuration.
+	FlavorMinIO: {
+		CapAccelerate:         false,
+		CapAnalytics:          false,
+		CapIntelligentTiering: false,
+		CapInventory:          false,
+	},
+	// Ceph RGW's support varies by build; treat the same optional calls as
+	// unsupported by default.
+	FlavorCeph: {
+		CapAccelerate:         false,
+		CapAnalytics:          false,
+		CapIntelligentTiering: false,
+		CapInventory:          false,
+	},
+}
+
+func (f Flavor) supports(cap Capability) bool {
+	if f == "" || f == FlavorAWS {
+		return true
+	}
+	unsupported, ok := capabilityMatrix[f]
+	if !ok {
+		return true
+	}
+	_, listed := unsupported[cap]
+	return !listed
+}
+
+// Endpoint configures a custom S3-compatible endpoint (MinIO, SeaweedFS,
+// Cloudflare R2, Ceph RGW, ...) instead of AWS's real endpoints.
+type Endpoint struct {
+	// URL is the base endpoint, e.g. "http://localhost:9000" for a local
+	// MinIO instance. Empty uses AWS's default endpoint resolution.
+	URL string
+	// PathStyle forces path-style bucket addressing (bucket.example.com/key
+	// becomes example.com/bucket/key), required by most non-AWS
+	// implementations.
+	PathStyle bool
+	// DisableSSL forces the http scheme on URL, whatever scheme it was
+	// given with.
+	DisableSSL bool
+	// Flavor identifies the implementation behind URL, used to skip API
+	// calls it doesn't support. Defaults to FlavorAWS (every call supported)
+	// when URL is empty.
+	Flavor Flavor
+	// Region is passed to the SDK as the client's region. Most
+	// S3-compatible implementations ignore its value but the SDK still
+	// requires one to be set. When empty, the region from the loaded AWS
+	// config (e.g. AWS_REGION or the profile) is used, falling back to
+	// "us-east-1" if that is empty too.
+	Region string
+}
+
+// resolverV2 implements s3.EndpointResolverV2 by always returning the same
+// custom base endpoint, optionally forcing path-style addressing.
+type resolverV2 struct {
+	base      *url.URL
+	pathStyle bool
+}
+
+func (r *resolverV2) ResolveEndpoint(ctx context.Context, params s3.EndpointParameters) (smithyendpoints.Endpoint, error) {
+	u := *r.base
+	if params.Bucket == nil {
+		// Bucket-less operations (e.g. ListBuckets) address the endpoint
+		// directly, in either style.
+		return smithyendpoints.Endpoint{URI: u}, nil
+	}
+	if r.pathStyle {
+		u.Path = "/" + *params.Bucket
+	} else {
+		// Virtual-hosted addressing: the bucket becomes a subdomain, not a
+		// path, so different buckets resolve to different hosts.
+		u.Host = *params.Bucket + "." + u.Host
+	}
+	return smithyendpoints.Endpoint{URI: u}, nil
+}
+
+func newResolverV2(endpoint Endpoint) (*resolverV2, error) {
+	u, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse endpoint URL %q: %w", endpoint.URL, err)
+	}
+	if endpoint.DisableSSL {
+		u.Scheme = "http"
+	}
+	return &resolverV2{base: u, pathStyle: endpoint.PathStyle}, nil
+}
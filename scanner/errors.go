@@ -0,0 +1,18 @@
+package scanner
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aws/smithy-go"
+)
+
+// containsCode reports whether err is a smithy API error with the given
+// code, or a generic error whose message contains it.
+func containsCode(err error, code string) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == code {
+		return true
+	}
+	return strings.Contains(err.Error(), code)
+}
@@ -0,0 +1,99 @@
+//go:build integration
+
+package scanner_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/testcontainers/testcontainers-go/modules/minio"
+
+	"github.com/optiowl-cloud/s3-bucket-scanner/scanner"
+)
+
+// TestScanner_AgainstMinIO spins up a real MinIO container and asserts the
+// scanner produces a valid BucketInfo against it end-to-end, including the
+// object inventory pass. Run with: go test -tags integration ./scanner/...
+func TestScanner_AgainstMinIO(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := minio.Run(ctx, "minio/minio:RELEASE.2024-01-16T16-07-38Z")
+	if err != nil {
+		t.Fatalf("start minio container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Fatalf("terminate minio container: %v", err)
+		}
+	})
+
+	endpoint, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("get minio connection string: %v", err)
+	}
+	endpointURL := "http://" + endpoint
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx,
+		awsconfig.WithRegion("us-east-1"),
+		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			container.Username, container.Password, "")),
+	)
+	if err != nil {
+		t.Fatalf("load AWS config: %v", err)
+	}
+
+	const bucket = "scanner-integration-test"
+	setupClient := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(endpointURL)
+		o.UsePathStyle = true
+	})
+	if _, err := setupClient.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(bucket)}); err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+	if _, err := setupClient.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String("hello.txt"),
+		Body:   strings.NewReader("hello"),
+	}); err != nil {
+		t.Fatalf("put object: %v", err)
+	}
+
+	s := scanner.New(scanner.Config{
+		Endpoint: scanner.Endpoint{
+			URL:       endpointURL,
+			PathStyle: true,
+			Flavor:    scanner.FlavorMinIO,
+			Region:    "us-east-1",
+		},
+		Objects: scanner.ObjectConfig{Enabled: true},
+	})
+
+	infos, bucketErrs, err := s.Scan(ctx)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	for _, e := range bucketErrs {
+		t.Logf("bucket error (expected for calls MinIO doesn't support): %s", e.Error())
+	}
+
+	var found *scanner.BucketInfo
+	for i := range infos {
+		if infos[i].Name == bucket {
+			found = &infos[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected to find bucket %q in scan results, got %d bucket(s)", bucket, len(infos))
+	}
+	if found.ACL == nil {
+		t.Errorf("expected ACL to be populated for %q", bucket)
+	}
+	if found.Objects == nil || found.Objects.Summary.TotalObjects != 1 {
+		t.Errorf("expected 1 object in inventory, got %+v", found.Objects)
+	}
+}
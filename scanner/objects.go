@@ -0,0 +1,166 @@
+package scanner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// ObjectConfig controls the optional per-object inventory pass run against
+// each bucket. Leave Enabled false to skip object-level collection and only
+// gather bucket-level configuration.
+type ObjectConfig struct {
+	Enabled bool
+	// Prefix restricts ListObjectsV2 to keys under this prefix.
+	Prefix string
+	// MaxObjects caps how many keys are inspected per bucket. 0 means no cap.
+	MaxObjects int
+	// SampleRate, if > 1, inspects only every Nth key (in listing order)
+	// instead of every key, for a cheap approximation of large buckets.
+	SampleRate int
+}
+
+// ObjectInfo is the per-key detail collected via HeadObject.
+type ObjectInfo struct {
+	Key                       string                     `json:"key"`
+	Size                      int64                      `json:"size"`
+	StorageClass              types.StorageClass         `json:"storageClass"`
+	LastModified              time.Time                  `json:"lastModified"`
+	ServerSideEncryption      types.ServerSideEncryption `json:"serverSideEncryption,omitempty"`
+	SSEKMSKeyID               string                     `json:"ssekmsKeyId,omitempty"`
+	SSECustomerAlgorithm      string                     `json:"ssecAlgorithm,omitempty"`
+	ObjectLockMode            types.ObjectLockMode       `json:"objectLockMode,omitempty"`
+	ObjectLockRetainUntilDate *time.Time                 `json:"objectLockRetainUntilDate,omitempty"`
+}
+
+// ObjectSummary aggregates encryption and storage-class posture across every
+// object inspected in a bucket.
+type ObjectSummary struct {
+	TotalObjects        int                          `json:"totalObjects"`
+	TotalBytes          int64                        `json:"totalBytes"`
+	UnencryptedObjects  int                          `json:"unencryptedObjects"`
+	SSES3Objects        int                          `json:"sseS3Objects"`
+	SSEKMSObjects       int                          `json:"sseKmsObjects"`
+	SSECObjects         int                          `json:"ssecObjects"`
+	BytesByStorageClass map[types.StorageClass]int64 `json:"bytesByStorageClass,omitempty"`
+}
+
+// ObjectInventory is the result of the per-object pass for one bucket: a
+// summary suitable for reporting, plus the individual objects inspected
+// (capped/sampled per ObjectConfig).
+type ObjectInventory struct {
+	Summary ObjectSummary `json:"summary"`
+	Objects []ObjectInfo  `json:"objects,omitempty"`
+}
+
+// collectObjects paginates ListObjectsV2 for j.bucket and issues HeadObject
+// on each selected key, bounded by the scanner's object-level worker pool
+// (independent from the bucket-level pool ScanStream uses, since the calling
+// goroutine already holds a bucket-level slot), to build an ObjectInventory.
+func (s *Scanner) collectObjects(ctx context.Context, j job) (*ObjectInventory, []BucketError) {
+	cfg := s.cfg.Objects
+	var (
+		keys  []string
+		seen  int
+		errs  []BucketError
+		input = &s3.ListObjectsV2Input{Bucket: &j.bucket}
+	)
+	if cfg.Prefix != "" {
+		input.Prefix = &cfg.Prefix
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(j.client, input)
+collect:
+	for paginator.HasMorePages() {
+		pageCtx, cancel := context.WithTimeout(ctx, s.cfg.CallTimeout)
+		page, err := paginator.NextPage(pageCtx)
+		cancel()
+		if err != nil {
+			errs = append(errs, BucketError{Bucket: j.bucket, Profile: j.account.Profile, Region: j.region, Call: "ListObjectsV2", Err: err})
+			break
+		}
+		for _, obj := range page.Contents {
+			seen++
+			if cfg.SampleRate > 1 && seen%cfg.SampleRate != 0 {
+				continue
+			}
+			keys = append(keys, aws.ToString(obj.Key))
+			if cfg.MaxObjects > 0 && len(keys) >= cfg.MaxObjects {
+				break collect
+			}
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		objects = make([]ObjectInfo, 0, len(keys))
+		wg      sync.WaitGroup
+	)
+
+	for _, key := range keys {
+		key := key
+		wg.Add(1)
+		s.objectSem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-s.objectSem }()
+
+			headCtx, cancel := context.WithTimeout(ctx, s.cfg.CallTimeout)
+			defer cancel()
+
+			var resp *s3.HeadObjectOutput
+			err := withRetry(headCtx, s.cfg.MaxRetries, func() error {
+				var headErr error
+				resp, headErr = j.client.HeadObject(headCtx, &s3.HeadObjectInput{Bucket: &j.bucket, Key: &key})
+				return headErr
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, BucketError{Bucket: j.bucket, Profile: j.account.Profile, Region: j.region, Call: "HeadObject " + key, Err: err})
+				return
+			}
+			objects = append(objects, ObjectInfo{
+				Key:                       key,
+				Size:                      aws.ToInt64(resp.ContentLength),
+				StorageClass:              resp.StorageClass,
+				LastModified:              aws.ToTime(resp.LastModified),
+				ServerSideEncryption:      resp.ServerSideEncryption,
+				SSEKMSKeyID:               aws.ToString(resp.SSEKMSKeyId),
+				SSECustomerAlgorithm:      aws.ToString(resp.SSECustomerAlgorithm),
+				ObjectLockMode:            resp.ObjectLockMode,
+				ObjectLockRetainUntilDate: resp.ObjectLockRetainUntilDate,
+			})
+		}()
+	}
+	wg.Wait()
+
+	summary := summarize(objects)
+	return &ObjectInventory{Summary: summary, Objects: objects}, errs
+}
+
+func summarize(objects []ObjectInfo) ObjectSummary {
+	summary := ObjectSummary{BytesByStorageClass: map[types.StorageClass]int64{}}
+	for _, obj := range objects {
+		summary.TotalObjects++
+		summary.TotalBytes += obj.Size
+		summary.BytesByStorageClass[obj.StorageClass] += obj.Size
+
+		switch {
+		case obj.SSECustomerAlgorithm != "":
+			summary.SSECObjects++
+		case obj.ServerSideEncryption == types.ServerSideEncryptionAwsKms || obj.ServerSideEncryption == types.ServerSideEncryptionAwsKmsDsse:
+			summary.SSEKMSObjects++
+		case obj.ServerSideEncryption == types.ServerSideEncryptionAes256:
+			summary.SSES3Objects++
+		default:
+			summary.UnencryptedObjects++
+		}
+	}
+	return summary
+}
@@ -0,0 +1,50 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aws/smithy-go"
+)
+
+// throttled reports whether err represents an S3 throttling response that is
+// worth retrying (SlowDown, RequestLimitExceeded, TooManyRequests).
+func throttled(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "SlowDown", "RequestLimitExceeded", "TooManyRequests", "Throttling", "ThrottlingException":
+			return true
+		}
+	}
+	return strings.Contains(err.Error(), "SlowDown") || strings.Contains(err.Error(), "RequestLimitExceeded")
+}
+
+// withRetry calls fn, retrying with exponential backoff and jitter while the
+// returned error is a throttling error, up to maxRetries attempts.
+func withRetry(ctx context.Context, maxRetries int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		err = fn()
+		if err == nil || !throttled(err) {
+			return err
+		}
+		if attempt == maxRetries {
+			break
+		}
+		backoff := time.Duration(1<<uint(attempt)) * 100 * time.Millisecond
+		backoff += time.Duration(rand.Int63n(int64(backoff) / 2))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+	return err
+}
@@ -0,0 +1,512 @@
+// Package scanner implements concurrent collection of S3 bucket
+// configuration and inventory across one or more AWS accounts and regions.
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// Scanner collects BucketInfo for every bucket visible to the configured
+// accounts, fanning per-bucket collection out across a bounded worker pool.
+type Scanner struct {
+	cfg Config
+	// sem bounds how many buckets ScanStream collects in parallel.
+	sem chan struct{}
+	// objectSem bounds how many HeadObject calls collectObjects issues in
+	// parallel. It's a separate channel from sem, not shared with it: a
+	// bucket-level goroutine holds a sem slot for as long as collectObjects
+	// runs, so if collectObjects acquired from that same channel, every
+	// in-flight bucket would block waiting for a slot only released by a
+	// collectObjects call that itself can't start without one - a deadlock
+	// once Concurrency buckets are in flight at once.
+	objectSem chan struct{}
+}
+
+// New returns a Scanner for the given Config, applying defaults for any
+// unset fields.
+func New(cfg Config) *Scanner {
+	cfg = cfg.withDefaults()
+	return &Scanner{
+		cfg:       cfg,
+		sem:       make(chan struct{}, cfg.Concurrency),
+		objectSem: make(chan struct{}, cfg.Concurrency),
+	}
+}
+
+// job is a single bucket to collect, scoped to the client that can see it.
+type job struct {
+	account Account
+	client  *s3.Client
+	region  string
+	bucket  string
+}
+
+// Scan lists buckets visible to every configured account and collects
+// BucketInfo for each, bounded by Config.Concurrency. Per-bucket failures are
+// aggregated into the returned []BucketError rather than aborting the scan;
+// the third return value is only non-nil for failures that prevent the scan
+// from running at all (e.g. a bad profile or failed AssumeRole).
+//
+// Scan buffers every BucketInfo in memory before returning; callers that
+// want to stream results as they're collected (e.g. to avoid buffering a
+// large estate before writing output) should use ScanStream instead.
+func (s *Scanner) Scan(ctx context.Context) ([]BucketInfo, []BucketError, error) {
+	var (
+		mu    sync.Mutex
+		infos []BucketInfo
+	)
+	errs, err := s.ScanStream(ctx, func(info BucketInfo) {
+		mu.Lock()
+		infos = append(infos, info)
+		mu.Unlock()
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(s.cfg.Regions) > 0 {
+		infos = filterByRegion(infos, s.cfg.Regions)
+	}
+
+	return infos, errs, nil
+}
+
+// ScanStream behaves like Scan but invokes onBucket as each BucketInfo is
+// collected instead of accumulating them, so a caller can stream results to
+// a Writer without buffering the whole scan in memory. onBucket is called
+// concurrently from multiple goroutines and must be safe for that. It is
+// called for every collected bucket: Config.Regions is applied only by Scan,
+// so ScanStream callers that want a region filter apply it in onBucket.
+//
+// A failure that prevents listing or locating buckets for one account is
+// recorded as a BucketError and that account (or bucket) is skipped, rather
+// than aborting the whole scan; the error return is reserved for failures
+// that leave nothing to scan at all (every configured account failed).
+func (s *Scanner) ScanStream(ctx context.Context, onBucket func(BucketInfo)) ([]BucketError, error) {
+	var (
+		jobs   []job
+		errs   []BucketError
+		listed int
+	)
+	for _, acct := range s.cfg.Accounts {
+		listClient, listRegion, err := s.newClient(ctx, acct, "")
+		if err != nil {
+			errs = append(errs, BucketError{Profile: acct.Profile, Call: "newClient", Err: err})
+			continue
+		}
+
+		listCtx, cancel := context.WithTimeout(ctx, s.cfg.CallTimeout)
+		resp, err := listClient.ListBuckets(listCtx, &s3.ListBucketsInput{})
+		cancel()
+		if err != nil {
+			errs = append(errs, BucketError{Profile: acct.Profile, Call: "ListBuckets", Err: err})
+			continue
+		}
+		listed++
+
+		// ListBuckets is account-global: it returns every bucket regardless
+		// of region, and a client built for one region will fail (or be
+		// silently redirected by the SDK, depending on call) against buckets
+		// that live elsewhere. Against real AWS, resolve each bucket's home
+		// region up front and collect it with a client built for that
+		// region, reusing one client per region instead of per bucket.
+		// Custom endpoints (MinIO, etc.) aren't meaningfully multi-region,
+		// so they keep using the single client built for Endpoint.Region.
+		regionClients := map[string]*s3.Client{listRegion: listClient}
+		for _, bucket := range resp.Buckets {
+			name := aws.ToString(bucket.Name)
+			client, region := listClient, listRegion
+			if s.cfg.Endpoint.URL == "" {
+				client, region, err = s.bucketRegionClient(ctx, acct, listClient, name, regionClients)
+				if err != nil {
+					errs = append(errs, BucketError{Bucket: name, Profile: acct.Profile, Call: "GetBucketLocation", Err: err})
+					continue
+				}
+			}
+			jobs = append(jobs, job{account: acct, client: client, region: region, bucket: name})
+		}
+	}
+
+	if listed == 0 {
+		return errs, fmt.Errorf("no configured account could list buckets (%d error(s)); first: %s", len(errs), errs[0].Error())
+	}
+	if len(jobs) == 0 {
+		return errs, nil
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, j := range jobs {
+		j := j
+		wg.Add(1)
+		s.sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-s.sem }()
+
+			info, bucketErrs := s.collectBucket(ctx, j)
+
+			mu.Lock()
+			errs = append(errs, bucketErrs...)
+			mu.Unlock()
+
+			onBucket(info)
+		}()
+	}
+	wg.Wait()
+
+	return errs, nil
+}
+
+// filterByRegion keeps only buckets whose resolved location is one of
+// regions, letting callers scope a scan to a subset of a large multi-region
+// estate.
+func filterByRegion(infos []BucketInfo, regions []string) []BucketInfo {
+	wanted := make(map[string]bool, len(regions))
+	for _, r := range regions {
+		wanted[r] = true
+	}
+	kept := infos[:0]
+	for _, info := range infos {
+		region := ""
+		if info.Location != nil {
+			region = string(info.Location.LocationConstraint)
+		}
+		if region == "" {
+			region = "us-east-1"
+		}
+		if wanted[region] {
+			kept = append(kept, info)
+		}
+	}
+	return kept
+}
+
+// bucketRegionClient resolves bucket's home region via GetBucketLocation
+// (issued with listClient, which can address any bucket regardless of its
+// region) and returns a client built for that region, reusing one from
+// regionClients when a prior bucket already resolved to the same region.
+func (s *Scanner) bucketRegionClient(ctx context.Context, acct Account, listClient *s3.Client, bucket string, regionClients map[string]*s3.Client) (*s3.Client, string, error) {
+	locCtx, cancel := context.WithTimeout(ctx, s.cfg.CallTimeout)
+	resp, err := listClient.GetBucketLocation(locCtx, &s3.GetBucketLocationInput{Bucket: &bucket})
+	cancel()
+	if err != nil {
+		return nil, "", fmt.Errorf("get bucket location: %w", err)
+	}
+
+	region := string(resp.LocationConstraint)
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	if client, ok := regionClients[region]; ok {
+		return client, region, nil
+	}
+	client, _, err := s.newClient(ctx, acct, region)
+	if err != nil {
+		return nil, "", err
+	}
+	regionClients[region] = client
+	return client, region, nil
+}
+
+// newClient builds an S3 client for account, assuming RoleARN via STS when
+// set. If region is empty the client uses the config's resolved default
+// region (falling back to us-east-1), which is sufficient for ListBuckets
+// and GetBucketLocation.
+func (s *Scanner) newClient(ctx context.Context, account Account, region string) (*s3.Client, string, error) {
+	opts := []func(*config.LoadOptions) error{}
+	if account.Profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(account.Profile))
+	}
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	} else if s.cfg.Endpoint.Region != "" {
+		opts = append(opts, config.WithRegion(s.cfg.Endpoint.Region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, "", fmt.Errorf("load config: %w", err)
+	}
+
+	if account.RoleARN != "" {
+		stsClient := sts.NewFromConfig(cfg)
+		provider := stscreds.NewAssumeRoleProvider(stsClient, account.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+			if account.ExternalID != "" {
+				o.ExternalID = aws.String(account.ExternalID)
+			}
+		})
+		cfg.Credentials = aws.NewCredentialsCache(provider)
+	}
+
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	if s.cfg.Endpoint.URL == "" {
+		return s3.NewFromConfig(cfg), cfg.Region, nil
+	}
+
+	resolver, err := newResolverV2(s.cfg.Endpoint)
+	if err != nil {
+		return nil, "", fmt.Errorf("custom endpoint: %w", err)
+	}
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.EndpointResolverV2 = resolver
+		o.UsePathStyle = s.cfg.Endpoint.PathStyle
+	})
+	return client, cfg.Region, nil
+}
+
+// collectBucket gathers every piece of BucketInfo for a single bucket,
+// retrying throttled calls and recording failed calls as BucketErrors
+// instead of aborting.
+func (s *Scanner) collectBucket(ctx context.Context, j job) (BucketInfo, []BucketError) {
+	info := BucketInfo{Name: j.bucket, Profile: j.account.Profile, Region: j.region}
+	var errs []BucketError
+
+	fail := func(call string, err error) {
+		errs = append(errs, BucketError{Bucket: j.bucket, Profile: j.account.Profile, Region: j.region, Call: call, Err: err})
+	}
+
+	call := func(name string, fn func(ctx context.Context) error) {
+		callCtx, cancel := context.WithTimeout(ctx, s.cfg.CallTimeout)
+		defer cancel()
+		if err := withRetry(callCtx, s.cfg.MaxRetries, func() error { return fn(callCtx) }); err != nil && !ignorable(err) {
+			fail(name, err)
+		}
+	}
+
+	// callIf is like call but skips the call entirely (no error recorded)
+	// when the endpoint's Flavor is known not to support cap, instead of
+	// letting an unsupported-subresource error surface as a BucketError.
+	callIf := func(cap Capability, name string, fn func(ctx context.Context) error) {
+		if !s.cfg.Endpoint.Flavor.supports(cap) {
+			return
+		}
+		call(name, fn)
+	}
+
+	callIf(CapAccelerate, "GetBucketAccelerateConfiguration", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketAccelerateConfiguration(ctx, &s3.GetBucketAccelerateConfigurationInput{Bucket: &j.bucket})
+		if err == nil {
+			info.AccelerateConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketAcl", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketAcl(ctx, &s3.GetBucketAclInput{Bucket: &j.bucket})
+		if err == nil {
+			info.ACL = resp
+		}
+		return err
+	})
+
+	callIf(CapAnalytics, "ListBucketAnalyticsConfigurations", func(ctx context.Context) error {
+		resp, err := j.client.ListBucketAnalyticsConfigurations(ctx, &s3.ListBucketAnalyticsConfigurationsInput{Bucket: &j.bucket})
+		if err != nil {
+			return err
+		}
+		for _, ac := range resp.AnalyticsConfigurationList {
+			acResp, err := j.client.GetBucketAnalyticsConfiguration(ctx, &s3.GetBucketAnalyticsConfigurationInput{Bucket: &j.bucket, Id: ac.Id})
+			if err != nil {
+				fail("GetBucketAnalyticsConfiguration", err)
+				continue
+			}
+			info.AnalyticsConfig = append(info.AnalyticsConfig, acResp)
+		}
+		return nil
+	})
+
+	call("GetBucketCors", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketCors(ctx, &s3.GetBucketCorsInput{Bucket: &j.bucket})
+		if err == nil {
+			info.CORSConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketEncryption", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketEncryption(ctx, &s3.GetBucketEncryptionInput{Bucket: &j.bucket})
+		if err == nil {
+			info.EncryptionConfig = resp
+		}
+		return err
+	})
+
+	callIf(CapIntelligentTiering, "ListBucketIntelligentTieringConfigurations", func(ctx context.Context) error {
+		resp, err := j.client.ListBucketIntelligentTieringConfigurations(ctx, &s3.ListBucketIntelligentTieringConfigurationsInput{Bucket: &j.bucket})
+		if err != nil {
+			return err
+		}
+		for _, itc := range resp.IntelligentTieringConfigurationList {
+			itResp, err := j.client.GetBucketIntelligentTieringConfiguration(ctx, &s3.GetBucketIntelligentTieringConfigurationInput{Bucket: &j.bucket, Id: itc.Id})
+			if err != nil {
+				fail("GetBucketIntelligentTieringConfiguration", err)
+				continue
+			}
+			info.IntelligentTieringConfig = append(info.IntelligentTieringConfig, itResp)
+		}
+		return nil
+	})
+
+	callIf(CapInventory, "ListBucketInventoryConfigurations", func(ctx context.Context) error {
+		resp, err := j.client.ListBucketInventoryConfigurations(ctx, &s3.ListBucketInventoryConfigurationsInput{Bucket: &j.bucket})
+		if err != nil {
+			return err
+		}
+		for _, ic := range resp.InventoryConfigurationList {
+			invResp, err := j.client.GetBucketInventoryConfiguration(ctx, &s3.GetBucketInventoryConfigurationInput{Bucket: &j.bucket, Id: ic.Id})
+			if err != nil {
+				fail("GetBucketInventoryConfiguration", err)
+				continue
+			}
+			info.InventoryConfig = append(info.InventoryConfig, invResp)
+		}
+		return nil
+	})
+
+	call("GetBucketLifecycleConfiguration", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: &j.bucket})
+		if err == nil {
+			info.LifecycleConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketLocation", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: &j.bucket})
+		if err == nil {
+			info.Location = resp
+		}
+		return err
+	})
+
+	call("GetBucketLogging", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketLogging(ctx, &s3.GetBucketLoggingInput{Bucket: &j.bucket})
+		if err == nil {
+			info.LoggingConfig = resp
+		}
+		return err
+	})
+
+	callIf(CapMetrics, "ListBucketMetricsConfigurations", func(ctx context.Context) error {
+		resp, err := j.client.ListBucketMetricsConfigurations(ctx, &s3.ListBucketMetricsConfigurationsInput{Bucket: &j.bucket})
+		if err != nil {
+			return err
+		}
+		for _, mc := range resp.MetricsConfigurationList {
+			mResp, err := j.client.GetBucketMetricsConfiguration(ctx, &s3.GetBucketMetricsConfigurationInput{Bucket: &j.bucket, Id: mc.Id})
+			if err != nil {
+				fail("GetBucketMetricsConfiguration", err)
+				continue
+			}
+			info.MetricsConfig = append(info.MetricsConfig, mResp)
+		}
+		return nil
+	})
+
+	call("GetBucketNotificationConfiguration", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{Bucket: &j.bucket})
+		if err == nil {
+			info.NotificationConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketOwnershipControls", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketOwnershipControls(ctx, &s3.GetBucketOwnershipControlsInput{Bucket: &j.bucket})
+		if err == nil {
+			info.OwnershipControlsConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketPolicy", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketPolicy(ctx, &s3.GetBucketPolicyInput{Bucket: &j.bucket})
+		if err == nil {
+			info.Policy = resp
+		}
+		return err
+	})
+
+	call("GetBucketPolicyStatus", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketPolicyStatus(ctx, &s3.GetBucketPolicyStatusInput{Bucket: &j.bucket})
+		if err == nil {
+			info.PolicyStatus = resp
+		}
+		return err
+	})
+
+	call("GetBucketReplication", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{Bucket: &j.bucket})
+		if err == nil {
+			info.ReplicationConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketRequestPayment", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketRequestPayment(ctx, &s3.GetBucketRequestPaymentInput{Bucket: &j.bucket})
+		if err == nil {
+			info.RequestPaymentConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketTagging", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: &j.bucket})
+		if err == nil {
+			info.TaggingConfig = resp
+		}
+		return err
+	})
+
+	call("GetBucketVersioning", func(ctx context.Context) error {
+		resp, err := j.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: &j.bucket})
+		if err == nil {
+			info.VersioningConfig = resp
+		}
+		return err
+	})
+
+	if s.cfg.Objects.Enabled {
+		objects, objectErrs := s.collectObjects(ctx, j)
+		info.Objects = objects
+		errs = append(errs, objectErrs...)
+	}
+
+	return info, errs
+}
+
+// ignorable reports whether err is an expected "no such sub-resource"
+// response that simply means the feature isn't configured on the bucket,
+// rather than a real failure worth surfacing as a BucketError.
+func ignorable(err error) bool {
+	for _, code := range []string{
+		"NoSuchCORSConfiguration",
+		"NoSuchLifecycleConfiguration",
+		"OwnershipControlsNotFoundError",
+		"NoSuchBucketPolicy",
+		"ReplicationConfigurationNotFoundError",
+		"NoSuchTagSet",
+	} {
+		if containsCode(err, code) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// BucketInfo represents the information collected about a single S3 bucket.
+type BucketInfo struct {
+	Name                     string                                               `json:"name"`
+	Profile                  string                                               `json:"profile"`
+	Region                   string                                               `json:"region"`
+	AccelerateConfig         *s3.GetBucketAccelerateConfigurationOutput           `json:"accelerateConfig,omitempty"`
+	ACL                      *s3.GetBucketAclOutput                               `json:"acl,omitempty"`
+	AnalyticsConfig          []*s3.GetBucketAnalyticsConfigurationOutput          `json:"analyticsConfig,omitempty"`
+	CORSConfig               *s3.GetBucketCorsOutput                              `json:"corsConfig,omitempty"`
+	EncryptionConfig         *s3.GetBucketEncryptionOutput                        `json:"encryptionConfig,omitempty"`
+	IntelligentTieringConfig []*s3.GetBucketIntelligentTieringConfigurationOutput `json:"intelligentTieringConfig,omitempty"`
+	InventoryConfig          []*s3.GetBucketInventoryConfigurationOutput          `json:"inventoryConfig,omitempty"`
+	LifecycleConfig          *s3.GetBucketLifecycleConfigurationOutput            `json:"lifecycleConfig,omitempty"`
+	Location                 *s3.GetBucketLocationOutput                          `json:"location,omitempty"`
+	LoggingConfig            *s3.GetBucketLoggingOutput                           `json:"loggingConfig,omitempty"`
+	MetricsConfig            []*s3.GetBucketMetricsConfigurationOutput            `json:"metricsConfig,omitempty"`
+	NotificationConfig       *s3.GetBucketNotificationConfigurationOutput         `json:"notificationConfig,omitempty"`
+	OwnershipControlsConfig  *s3.GetBucketOwnershipControlsOutput                 `json:"ownershipControlsConfig,omitempty"`
+	Policy                   *s3.GetBucketPolicyOutput                            `json:"policy,omitempty"`
+	PolicyStatus             *s3.GetBucketPolicyStatusOutput                      `json:"policyStatus,omitempty"`
+	ReplicationConfig        *s3.GetBucketReplicationOutput                       `json:"replicationConfig,omitempty"`
+	RequestPaymentConfig     *s3.GetBucketRequestPaymentOutput                    `json:"requestPaymentConfig,omitempty"`
+	TaggingConfig            *s3.GetBucketTaggingOutput                           `json:"taggingConfig,omitempty"`
+	VersioningConfig         *s3.GetBucketVersioningOutput                        `json:"versioningConfig,omitempty"`
+	Objects                  *ObjectInventory                                     `json:"objects,omitempty"`
+}
+
+// BucketError records a failure collecting some piece of bucket information
+// without aborting the rest of the scan.
+type BucketError struct {
+	Bucket  string `json:"bucket"`
+	Profile string `json:"profile"`
+	Region  string `json:"region"`
+	Call    string `json:"call"`
+	Err     error  `json:"-"`
+}
+
+func (e *BucketError) Error() string {
+	return e.Call + " on " + e.Bucket + ": " + e.Err.Error()
+}
+
+// MarshalJSON flattens the wrapped error into a string so BucketError is
+// usable as plain output (error.Error() isn't marshaled by default).
+func (e *BucketError) MarshalJSON() ([]byte, error) {
+	type alias struct {
+		Bucket  string `json:"bucket"`
+		Profile string `json:"profile"`
+		Region  string `json:"region"`
+		Call    string `json:"call"`
+		Message string `json:"message"`
+	}
+	return json.Marshal(alias{
+		Bucket:  e.Bucket,
+		Profile: e.Profile,
+		Region:  e.Region,
+		Call:    e.Call,
+		Message: e.Err.Error(),
+	})
+}